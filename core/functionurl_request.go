@@ -0,0 +1,68 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// RequestAccessorFunctionURL converts a Lambda Function URL request event
+// into an http.Request compatible with the fiber.App.
+type RequestAccessorFunctionURL struct {
+}
+
+// NewRequestAccessorFunctionURL creates a new RequestAccessorFunctionURL
+// object, use it instead of instantiating RequestAccessorFunctionURL directly.
+func NewRequestAccessorFunctionURL() *RequestAccessorFunctionURL {
+	return &RequestAccessorFunctionURL{}
+}
+
+// EventToRequestWithContext converts a Lambda Function URL request event and
+// a context object into an http.Request object.
+func (r *RequestAccessorFunctionURL) EventToRequestWithContext(ctx context.Context, req events.LambdaFunctionURLRequest) (*http.Request, error) {
+	body := []byte(req.Body)
+	if req.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode base64 request body: %v", err)
+		}
+		body = decoded
+	}
+
+	path := req.RawPath
+	if req.RawQueryString != "" {
+		path = path + "?" + req.RawQueryString
+	}
+
+	httpRequest, err := http.NewRequest(req.RequestContext.HTTP.Method, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not convert function URL event to request: %v", err)
+	}
+
+	httpRequest = httpRequest.WithContext(ctx)
+	httpRequest.Host = req.RequestContext.DomainName
+	if sourceIP := req.RequestContext.HTTP.SourceIP; sourceIP != "" {
+		httpRequest.RemoteAddr = sourceIP + ":0"
+	}
+
+	for header, value := range req.Headers {
+		httpRequest.Header.Add(header, value)
+	}
+	if len(req.Cookies) > 0 {
+		// RFC 6265 S5.4 requires a single Cookie header with cookies
+		// joined by "; "; a Cookie header per cookie looks equivalent to
+		// net/http but fasthttp's cookie parser (which streaming.go hands
+		// these headers off to) only reads the first Cookie header
+		// occurrence, silently dropping the rest.
+		httpRequest.Header.Set("Cookie", strings.Join(req.Cookies, "; "))
+	}
+
+	httpRequest.RequestURI = httpRequest.URL.RequestURI()
+
+	return httpRequest, nil
+}
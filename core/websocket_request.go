@@ -0,0 +1,91 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// RequestAccessorWebsocket objects give access to custom request properties
+// and convert an API Gateway WebSocket proxy event into an http.Request
+// compatible with the fiber.App, mirroring RequestAccessorALB.
+type RequestAccessorWebsocket struct {
+}
+
+// NewRequestAccessorWebsocket creates a new RequestAccessorWebsocket object,
+// use it instead of instantiating RequestAccessorWebsocket directly.
+func NewRequestAccessorWebsocket() *RequestAccessorWebsocket {
+	return &RequestAccessorWebsocket{}
+}
+
+// ProxyEventToHTTPRequest converts an API Gateway WebSocket proxy event into
+// an http.Request object.
+// Returns the populated http request with context.Background() as its context.
+func (r *RequestAccessorWebsocket) ProxyEventToHTTPRequest(req events.APIGatewayWebsocketProxyRequest) (*http.Request, error) {
+	return r.EventToRequestWithContext(context.Background(), req)
+}
+
+// EventToRequestWithContext converts an API Gateway WebSocket proxy event and
+// a context object into an http.Request object.
+// A WebSocket event carries no HTTP path of its own, so the route key
+// ($connect, $disconnect, $default, or a custom route selection key) becomes
+// the request path a fiber.App route is registered under.
+func (r *RequestAccessorWebsocket) EventToRequestWithContext(ctx context.Context, req events.APIGatewayWebsocketProxyRequest) (*http.Request, error) {
+	body := []byte(req.Body)
+	if req.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode base64 websocket body: %v", err)
+		}
+		body = decoded
+	}
+
+	httpRequest, err := http.NewRequest(
+		r.httpMethod(req),
+		r.routeKeyToPath(req.RequestContext.RouteKey),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert websocket proxy event to request: %v", err)
+	}
+
+	httpRequest = httpRequest.WithContext(ctx)
+
+	for header, value := range req.Headers {
+		httpRequest.Header.Add(header, value)
+	}
+
+	httpRequest.RequestURI = httpRequest.URL.RequestURI()
+
+	return httpRequest, nil
+}
+
+// httpMethod returns the HTTP method to dispatch the route key under.
+// $connect, $disconnect, and $default carry no HTTP method of their own in
+// the WebSocket event, so they are routed as GET; a custom route selection
+// key reuses whatever HTTPMethod the event reports, if any.
+func (r *RequestAccessorWebsocket) httpMethod(req events.APIGatewayWebsocketProxyRequest) string {
+	switch req.RequestContext.RouteKey {
+	case "$connect", "$disconnect", "$default":
+		return http.MethodGet
+	default:
+		if req.HTTPMethod != "" {
+			return req.HTTPMethod
+		}
+		return http.MethodGet
+	}
+}
+
+// routeKeyToPath maps a WebSocket route selection key to the path a fiber
+// route is registered under, e.g. "$connect" becomes "/$connect".
+func (r *RequestAccessorWebsocket) routeKeyToPath(routeKey string) string {
+	if strings.HasPrefix(routeKey, "/") {
+		return routeKey
+	}
+	return "/" + routeKey
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigatewaymanagementapi"
+)
+
+// ProxyResponseWriterWebsocket implements http.ResponseWriter and adds the
+// method necessary to return an events.APIGatewayProxyResponse object.
+// It is used for the $connect/$disconnect/$default lifecycle responses
+// returned directly to API Gateway; messages pushed to the client instead
+// go through a ConnectionSender.
+type ProxyResponseWriterWebsocket struct {
+	headers    http.Header
+	body       bytes.Buffer
+	statusCode int
+}
+
+// NewProxyResponseWriterWebsocket creates a new ProxyResponseWriterWebsocket
+// object. Its zero value is not usable as its header map is not initialized.
+func NewProxyResponseWriterWebsocket() *ProxyResponseWriterWebsocket {
+	return &ProxyResponseWriterWebsocket{
+		headers: make(http.Header),
+	}
+}
+
+// Header implements the http.ResponseWriter interface.
+func (r *ProxyResponseWriterWebsocket) Header() http.Header {
+	return r.headers
+}
+
+// Write implements the http.ResponseWriter interface and sets the default
+// status code to 200 in case WriteHeader was not called.
+func (r *ProxyResponseWriterWebsocket) Write(body []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	return r.body.Write(body)
+}
+
+// WriteHeader implements the http.ResponseWriter interface.
+func (r *ProxyResponseWriterWebsocket) WriteHeader(status int) {
+	r.statusCode = status
+}
+
+// GetProxyResponse converts the data written to the response writer into an
+// events.APIGatewayProxyResponse object. Returns a populated response
+// object; this method should not be called before the response writer has
+// been written to, as its status code would still be 0.
+func (r *ProxyResponseWriterWebsocket) GetProxyResponse() (events.APIGatewayProxyResponse, error) {
+	if r.statusCode == 0 {
+		return events.APIGatewayProxyResponse{}, NewLoggedError("Status code not set on response writer")
+	}
+
+	headers := make(map[string]string)
+	for key := range r.headers {
+		headers[key] = r.headers.Get(key)
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: r.statusCode,
+		Headers:    headers,
+		Body:       r.body.String(),
+	}, nil
+}
+
+// GatewayTimeoutWebsocket returns a dafault Gateway Timeout (504) response
+// for the $connect/$disconnect/$default lifecycle routes.
+func GatewayTimeoutWebsocket() events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{StatusCode: http.StatusGatewayTimeout}
+}
+
+// ConnectionSender posts messages back to a connected WebSocket client via
+// the API Gateway Management API. One is created per invocation, scoped to
+// the domain name and stage the triggering event was received on.
+type ConnectionSender struct {
+	client *apigatewaymanagementapi.ApiGatewayManagementApi
+}
+
+// NewConnectionSender builds a ConnectionSender that targets the management
+// API endpoint for the given domain name and stage.
+func NewConnectionSender(sess *session.Session, domainName, stage string) *ConnectionSender {
+	endpoint := fmt.Sprintf("https://%s/%s", domainName, stage)
+	return &ConnectionSender{
+		client: apigatewaymanagementapi.New(sess, aws.NewConfig().WithEndpoint(endpoint)),
+	}
+}
+
+// PostToConnection sends data to the client identified by connectionID.
+func (s *ConnectionSender) PostToConnection(connectionID string, data []byte) error {
+	_, err := s.client.PostToConnection(&apigatewaymanagementapi.PostToConnectionInput{
+		ConnectionId: aws.String(connectionID),
+		Data:         data,
+	})
+	if err != nil {
+		return fmt.Errorf("could not post to connection %s: %v", connectionID, err)
+	}
+	return nil
+}
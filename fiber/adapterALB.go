@@ -5,11 +5,14 @@ package fiberadapter
 
 import (
 	"context"
-	"io/ioutil"
+	"io"
 	"net"
 	"net/http"
+	"runtime/debug"
+	"sync"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/utils"
 	"github.com/valyala/fasthttp"
@@ -23,6 +26,34 @@ import (
 type FiberLambdaALB struct {
 	core.RequestAccessorALB
 	app *fiber.App
+	// awsSession backs the ConnectionSender passed to handlers by
+	// ProxyWebSocket; see AWSSession and WithAWSSession.
+	awsSession *session.Session
+	// awsSessionOnce guards the lazy session.NewSession call in AWSSession
+	// against concurrent first invocations racing to initialize awsSession.
+	awsSessionOnce sync.Once
+	// RecoveryHandler is invoked with the recovered value and stack trace
+	// when a panic escapes the wrapped fiber.App, so callers can report it
+	// (e.g. to Sentry or an OpenTelemetry exporter) and shape the response
+	// returned to the ALB. When nil, a panic still yields a generic 500
+	// response but is otherwise swallowed.
+	RecoveryHandler func(ctx context.Context, req events.ALBTargetGroupRequest, recovered interface{}, stack []byte) events.ALBTargetGroupResponse
+	// WebSocketRecoveryHandler is RecoveryHandler's counterpart for the
+	// ProxyWebSocket/ProxyWebSocketWithContext path: it's invoked with the
+	// recovered value and stack trace when a panic escapes the wrapped
+	// fiber.App while handling a $connect/$disconnect/$default or custom
+	// WebSocket route. When nil, a panic still yields a generic 500
+	// response but is otherwise swallowed.
+	WebSocketRecoveryHandler func(ctx context.Context, req events.APIGatewayWebsocketProxyRequest, recovered interface{}, stack []byte) events.APIGatewayProxyResponse
+	// forwardTargets, when non-empty, makes the adaptor bypass app
+	// entirely and forward requests to an upstream target instead; see
+	// Forward, WithTargets and WithTLSConfig.
+	forwardTargets []string
+	forwardClient  *fasthttp.Client
+	forwardNext    uint32
+	// forwardTLS is set by WithTLSConfig; it makes forward dial
+	// forwardTargets over TLS instead of defaulting to plain HTTP.
+	forwardTLS bool
 }
 
 // New creates a new instance of the FiberLambda object.
@@ -38,8 +69,7 @@ func NewALB(app *fiber.App) *FiberLambdaALB {
 // object, and sends it to the fiber.App for routing.
 // It returns a proxy response object generated from the http.ResponseWriter.
 func (f *FiberLambdaALB) Proxy(req events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
-	fiberRequest, err := f.ProxyEventToHTTPRequest(req)
-	return f.proxyInternal(fiberRequest, err)
+	return f.ProxyWithContext(context.Background(), req)
 }
 
 // ProxyWithContext receives context and an API Gateway proxy event,
@@ -47,14 +77,29 @@ func (f *FiberLambdaALB) Proxy(req events.ALBTargetGroupRequest) (events.ALBTarg
 // It returns a proxy response object generated from the http.ResponseWriter.
 func (f *FiberLambdaALB) ProxyWithContext(ctx context.Context, req events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
 	fiberRequest, err := f.EventToRequestWithContext(ctx, req)
-	return f.proxyInternal(fiberRequest, err)
+	return f.proxyInternal(ctx, req, fiberRequest, err)
 }
 
-func (f *FiberLambdaALB) proxyInternal(req *http.Request, err error) (events.ALBTargetGroupResponse, error) {
+func (f *FiberLambdaALB) proxyInternal(ctx context.Context, albReq events.ALBTargetGroupRequest, req *http.Request, err error) (resp events.ALBTargetGroupResponse, respErr error) {
 	if err != nil {
 		return core.GatewayTimeoutALB(), core.NewLoggedError("Could not convert proxy event to request: %v", err)
 	}
 
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			stack := debug.Stack()
+			if f.RecoveryHandler != nil {
+				resp, respErr = f.RecoveryHandler(ctx, albReq, recovered, stack), nil
+				return
+			}
+			resp, respErr = synthesizeALB500Response(), nil
+		}
+	}()
+
+	// core.ProxyResponseWriterALB exposes no Reset method, so it can't be
+	// safely pooled from here without risking a previous invocation's
+	// headers or body leaking into the next one; only the body-copy buffer
+	// below is pooled.
 	respWriter := core.NewProxyResponseWriterALB()
 	f.adaptor(http.ResponseWriter(respWriter), req)
 
@@ -66,19 +111,29 @@ func (f *FiberLambdaALB) proxyInternal(req *http.Request, err error) (events.ALB
 	return proxyResponse, nil
 }
 
+// copyBufferPool backs the io.CopyBuffer call in adaptor so reading the
+// incoming body doesn't allocate a fresh scratch buffer on every invocation.
+var copyBufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 32*1024)
+		return &buf
+	},
+}
+
 func (f *FiberLambdaALB) adaptor(w http.ResponseWriter, r *http.Request) {
 	// New fasthttp request
 	req := fasthttp.AcquireRequest()
 	defer fasthttp.ReleaseRequest(req)
 
-	// Convert net/http -> fasthttp request
-	body, err := ioutil.ReadAll(r.Body)
-	if err != nil {
+	// Convert net/http -> fasthttp request, copying the body straight into
+	// the fasthttp request's own buffer instead of buffering it twice.
+	bufPtr := copyBufferPool.Get().(*[]byte)
+	defer copyBufferPool.Put(bufPtr)
+	if _, err := io.CopyBuffer(req.BodyWriter(), r.Body, *bufPtr); err != nil {
 		http.Error(w, utils.StatusMessage(fiber.StatusInternalServerError), fiber.StatusInternalServerError)
 		return
 	}
-	req.Header.SetContentLength(len(body))
-	_, _ = req.BodyWriter().Write(body)
+	req.Header.SetContentLength(len(req.Body()))
 
 	req.Header.SetMethod(r.Method)
 	req.SetRequestURI(r.RequestURI)
@@ -98,13 +153,23 @@ func (f *FiberLambdaALB) adaptor(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if f.forwardClient != nil {
+		f.forward(w, req)
+		return
+	}
+
 	remoteAddr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr)
 	if err != nil {
 		http.Error(w, utils.StatusMessage(fiber.StatusInternalServerError), fiber.StatusInternalServerError)
 		return
 	}
 
-	// New fasthttp Ctx
+	// fasthttp.RequestCtx isn't pooled here: Init only copies req onto
+	// ctx.Request, it never touches ctx.Response or the user values a
+	// handler sets via SetUserValue/Locals (those are cleared by fasthttp's
+	// own unexported ctx.reset(), which this adapter never calls). Pooling
+	// the RequestCtx would leak a previous invocation's response headers,
+	// cookies, or Locals into a later, unrelated one under concurrent load.
 	var fctx fasthttp.RequestCtx
 	fctx.Init(req, remoteAddr, nil)
 
@@ -121,4 +186,4 @@ func (f *FiberLambdaALB) adaptor(w http.ResponseWriter, r *http.Request) {
 
 	// Set response body
 	_, _ = w.Write(fctx.Response.Body())
-}
\ No newline at end of file
+}
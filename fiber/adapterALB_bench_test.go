@@ -0,0 +1,117 @@
+package fiberadapter
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+)
+
+func benchApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/ping", func(c *fiber.Ctx) error {
+		return c.SendString("pong")
+	})
+	return app
+}
+
+func benchALBRequest() events.ALBTargetGroupRequest {
+	return events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/ping",
+		Headers:    map[string]string{"Host": "example.com"},
+	}
+}
+
+// BenchmarkProxyALB_Pooled exercises FiberLambdaALB.Proxy as shipped, which
+// pools its body-copy buffer across invocations. It no longer pools
+// fasthttp.RequestCtx itself (see the comment on fctx in adaptor), since
+// doing so leaked a previous invocation's response state into the next one.
+func BenchmarkProxyALB_Pooled(b *testing.B) {
+	lambdaFiber := NewALB(benchApp())
+	req := benchALBRequest()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := lambdaFiber.Proxy(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// unpooledAdaptor mirrors this package's adaptor before its body-copy buffer
+// was pooled: a full ioutil.ReadAll of the body on every call (both it and
+// adaptor use a fresh fasthttp.RequestCtx per call). It exists only to give
+// BenchmarkProxyALB_Unpooled a baseline to
+// compare BenchmarkProxyALB_Pooled against under `go test -bench`.
+func unpooledAdaptor(app *fiber.App, w http.ResponseWriter, r *http.Request) {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, utils.StatusMessage(fiber.StatusInternalServerError), fiber.StatusInternalServerError)
+		return
+	}
+	req.Header.SetContentLength(len(body))
+	_, _ = req.BodyWriter().Write(body)
+
+	req.Header.SetMethod(r.Method)
+	req.SetRequestURI(r.RequestURI)
+	req.SetHost(r.Host)
+	for key, val := range r.Header {
+		for _, v := range val {
+			req.Header.Add(key, v)
+		}
+	}
+
+	remoteAddr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr)
+	if err != nil {
+		http.Error(w, utils.StatusMessage(fiber.StatusInternalServerError), fiber.StatusInternalServerError)
+		return
+	}
+
+	var fctx fasthttp.RequestCtx
+	fctx.Init(req, remoteAddr, nil)
+
+	app.Handler()(&fctx)
+
+	fctx.Response.Header.VisitAll(func(k, v []byte) {
+		w.Header().Add(utils.UnsafeString(k), utils.UnsafeString(v))
+	})
+	w.WriteHeader(fctx.Response.StatusCode())
+	_, _ = w.Write(fctx.Response.Body())
+}
+
+// BenchmarkProxyALB_Unpooled runs the same request through unpooledAdaptor,
+// as a baseline for BenchmarkProxyALB_Pooled.
+func BenchmarkProxyALB_Unpooled(b *testing.B) {
+	app := benchApp()
+	albReq := benchALBRequest()
+	accessor := core.RequestAccessorALB{}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			httpReq, err := accessor.EventToRequestWithContext(context.Background(), albReq)
+			if err != nil {
+				b.Fatal(err)
+			}
+			respWriter := core.NewProxyResponseWriterALB()
+			unpooledAdaptor(app, respWriter, httpReq)
+			if _, err := respWriter.GetProxyResponse(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package fiberadapter
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
+)
+
+// Forward creates a FiberLambdaALB that forwards every ALB request
+// straight to target using client, instead of dispatching it through a
+// fiber.App. This lets a non-Fiber HTTP server - a sidecar container on
+// the same Lambda image, or an internal VPC endpoint - sit behind the ALB
+// adaptor unmodified. Use WithTargets to balance round-robin across
+// several upstream targets, and WithTLSConfig to dial them over TLS; the
+// scheme forwarded requests are built with follows WithTLSConfig, since
+// the ALB event they originate from carries no scheme of its own.
+func Forward(target string, client *fasthttp.Client, opts ...ForwardOption) *FiberLambdaALB {
+	if client == nil {
+		client = &fasthttp.Client{}
+	}
+
+	f := &FiberLambdaALB{
+		forwardTargets: []string{target},
+		forwardClient:  client,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// ForwardOption customizes a FiberLambdaALB created with Forward.
+type ForwardOption func(*FiberLambdaALB)
+
+// WithTLSConfig sets the TLS configuration the forwarding client dials
+// upstream targets with, and marks every forwardTarget as requiring TLS so
+// forward sets the outgoing request's URI scheme to "https" accordingly.
+func WithTLSConfig(cfg *tls.Config) ForwardOption {
+	return func(f *FiberLambdaALB) {
+		f.forwardClient.TLSConfig = cfg
+		f.forwardTLS = true
+	}
+}
+
+// WithTargets adds additional upstream targets, alongside the one passed to
+// Forward, that requests are balanced across round-robin.
+func WithTargets(targets ...string) ForwardOption {
+	return func(f *FiberLambdaALB) {
+		f.forwardTargets = append(f.forwardTargets, targets...)
+	}
+}
+
+// forward sends req to the next upstream target via f.forwardClient and
+// relays the upstream response back as-is.
+func (f *FiberLambdaALB) forward(w http.ResponseWriter, req *fasthttp.Request) {
+	target := f.nextForwardTarget()
+	req.SetHost(target)
+	req.Header.SetHost(target)
+	// req was built from the ALB event's bare path via SetRequestURI, so
+	// its URI scheme defaults to "http"; fasthttp.Client.Do dials TLS or
+	// not based solely on that scheme, so it must be set explicitly here
+	// for WithTLSConfig to actually take effect.
+	if f.forwardTLS {
+		req.URI().SetScheme("https")
+	}
+
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	if err := f.forwardClient.Do(req, resp); err != nil {
+		http.Error(w, utils.StatusMessage(fiber.StatusBadGateway), fiber.StatusBadGateway)
+		return
+	}
+
+	resp.Header.VisitAll(func(k, v []byte) {
+		w.Header().Add(utils.UnsafeString(k), utils.UnsafeString(v))
+	})
+	w.WriteHeader(resp.StatusCode())
+	_, _ = w.Write(resp.Body())
+}
+
+// nextForwardTarget returns the next upstream target, round-robining across
+// f.forwardTargets when more than one was configured via WithTargets.
+func (f *FiberLambdaALB) nextForwardTarget() string {
+	if len(f.forwardTargets) == 1 {
+		return f.forwardTargets[0]
+	}
+	idx := atomic.AddUint32(&f.forwardNext, 1) - 1
+	return f.forwardTargets[int(idx)%len(f.forwardTargets)]
+}
@@ -0,0 +1,65 @@
+package fiberadapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+func TestForwardRelaysToUpstream(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ping" {
+			t.Errorf("expected path /ping, got %q", r.URL.Path)
+		}
+		w.Header().Set("X-Upstream", "yes")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("pong"))
+	}))
+	defer upstream.Close()
+
+	target := upstream.Listener.Addr().String()
+	lambdaFiber := Forward(target, &fasthttp.Client{})
+
+	req := events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/ping",
+		Headers:    map[string]string{"Host": target},
+	}
+
+	resp, err := lambdaFiber.ProxyWithContext(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if resp.Body != "pong" {
+		t.Fatalf("expected body %q, got %q", "pong", resp.Body)
+	}
+	if resp.Headers["X-Upstream"] != "yes" {
+		t.Fatalf("expected upstream header to be relayed, got %v", resp.Headers)
+	}
+}
+
+func TestForwardBadGatewayOnUnreachableTarget(t *testing.T) {
+	lambdaFiber := Forward("127.0.0.1:1", &fasthttp.Client{})
+
+	req := events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/ping",
+		Headers:    map[string]string{"Host": "127.0.0.1:1"},
+	}
+
+	resp, err := lambdaFiber.ProxyWithContext(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadGateway {
+		t.Fatalf("expected status 502, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,34 @@
+package fiberadapter
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// synthesizeALB500Response builds a minimal but valid ALB target group
+// response for a panic that was not claimed by a FiberLambdaALB.RecoveryHandler.
+func synthesizeALB500Response() events.ALBTargetGroupResponse {
+	return events.ALBTargetGroupResponse{
+		StatusCode:        http.StatusInternalServerError,
+		StatusDescription: fmt.Sprintf("%d %s", http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)),
+		Headers: map[string]string{
+			"Content-Type": "text/plain; charset=utf-8",
+		},
+		Body: http.StatusText(http.StatusInternalServerError),
+	}
+}
+
+// synthesizeWebsocket500Response builds a minimal but valid API Gateway
+// WebSocket route response for a panic that was not claimed by a
+// FiberLambdaALB.WebSocketRecoveryHandler.
+func synthesizeWebsocket500Response() events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusInternalServerError,
+		Headers: map[string]string{
+			"Content-Type": "text/plain; charset=utf-8",
+		},
+		Body: http.StatusText(http.StatusInternalServerError),
+	}
+}
@@ -0,0 +1,137 @@
+package fiberadapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gofiber/fiber/v2"
+)
+
+func panicApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		panic("kaboom")
+	})
+	return app
+}
+
+func panicALBRequest() events.ALBTargetGroupRequest {
+	return events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/boom",
+		Headers:    map[string]string{"Host": "example.com"},
+	}
+}
+
+func TestProxyRecoversPanicWithDefaultResponse(t *testing.T) {
+	lambdaFiber := NewALB(panicApp())
+
+	resp, err := lambdaFiber.ProxyWithContext(context.Background(), panicALBRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", resp.StatusCode)
+	}
+	if resp.Body != http.StatusText(http.StatusInternalServerError) {
+		t.Fatalf("unexpected body: %q", resp.Body)
+	}
+}
+
+func TestProxyRecoversPanicWithRecoveryHandler(t *testing.T) {
+	lambdaFiber := NewALB(panicApp())
+
+	var gotRecovered interface{}
+	var gotStack []byte
+	lambdaFiber.RecoveryHandler = func(ctx context.Context, req events.ALBTargetGroupRequest, recovered interface{}, stack []byte) events.ALBTargetGroupResponse {
+		gotRecovered = recovered
+		gotStack = stack
+		return events.ALBTargetGroupResponse{
+			StatusCode: http.StatusTeapot,
+			Body:       "handled",
+		}
+	}
+
+	resp, err := lambdaFiber.ProxyWithContext(context.Background(), panicALBRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusTeapot {
+		t.Fatalf("expected status %d, got %d", http.StatusTeapot, resp.StatusCode)
+	}
+	if resp.Body != "handled" {
+		t.Fatalf("unexpected body: %q", resp.Body)
+	}
+	if gotRecovered != "kaboom" {
+		t.Fatalf("expected recovered value %q, got %v", "kaboom", gotRecovered)
+	}
+	if len(gotStack) == 0 {
+		t.Fatal("expected a non-empty stack trace")
+	}
+}
+
+func panicWebSocketApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/$default", func(c *fiber.Ctx) error {
+		panic("kaboom")
+	})
+	return app
+}
+
+func panicWebSocketRequest() events.APIGatewayWebsocketProxyRequest {
+	return events.APIGatewayWebsocketProxyRequest{
+		RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+			RouteKey:     "$default",
+			ConnectionID: "abc123",
+		},
+	}
+}
+
+func TestProxyWebSocketRecoversPanicWithDefaultResponse(t *testing.T) {
+	lambdaFiber := NewALB(panicWebSocketApp())
+
+	resp, err := lambdaFiber.ProxyWebSocketWithContext(context.Background(), panicWebSocketRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", resp.StatusCode)
+	}
+	if resp.Body != http.StatusText(http.StatusInternalServerError) {
+		t.Fatalf("unexpected body: %q", resp.Body)
+	}
+}
+
+func TestProxyWebSocketRecoversPanicWithRecoveryHandler(t *testing.T) {
+	lambdaFiber := NewALB(panicWebSocketApp())
+
+	var gotRecovered interface{}
+	var gotStack []byte
+	lambdaFiber.WebSocketRecoveryHandler = func(ctx context.Context, req events.APIGatewayWebsocketProxyRequest, recovered interface{}, stack []byte) events.APIGatewayProxyResponse {
+		gotRecovered = recovered
+		gotStack = stack
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusTeapot,
+			Body:       "handled",
+		}
+	}
+
+	resp, err := lambdaFiber.ProxyWebSocketWithContext(context.Background(), panicWebSocketRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusTeapot {
+		t.Fatalf("expected status %d, got %d", http.StatusTeapot, resp.StatusCode)
+	}
+	if resp.Body != "handled" {
+		t.Fatalf("unexpected body: %q", resp.Body)
+	}
+	if gotRecovered != "kaboom" {
+		t.Fatalf("expected recovered value %q, got %v", "kaboom", gotRecovered)
+	}
+	if len(gotStack) == 0 {
+		t.Fatal("expected a non-empty stack trace")
+	}
+}
@@ -0,0 +1,288 @@
+package fiberadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+)
+
+// DefaultStreamThreshold is the StreamThreshold a FiberLambdaStreaming uses
+// when NewStreaming is called without overriding it afterwards.
+const DefaultStreamThreshold = 6 * 1024 * 1024
+
+const (
+	runtimeAPIEnvVar = "AWS_LAMBDA_RUNTIME_API"
+	// streamingResponseModeHeader switches a Runtime API response payload
+	// into response streaming mode; see the Lambda developer guide's
+	// "Response streaming for custom runtimes and Lambda extensions".
+	streamingResponseModeHeader = "Lambda-Runtime-Function-Response-Mode"
+	streamingResponseMode       = "streaming"
+	streamingContentType        = "application/vnd.awslambda.http-integration-response"
+)
+
+// streamingPrelude is the JSON metadata the Runtime API expects ahead of
+// the 8-byte null separator and the body, once a response has been put in
+// streaming mode via streamingResponseModeHeader.
+type streamingPrelude struct {
+	StatusCode int               `json:"statusCode"`
+	Cookies    []string          `json:"cookies,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+}
+
+// FiberLambdaStreaming adapts a fiber.App to Lambda's Function URL response
+// streaming mode (InvokeWithResponseStream). aws-lambda-go has no built-in
+// support for returning a streamed response from a lambda.Start handler, so
+// FiberLambdaStreaming bypasses it and polls the Lambda Runtime API
+// directly, POSTing each response back chunked as it's produced instead of
+// buffering it into an events struct.
+type FiberLambdaStreaming struct {
+	core.RequestAccessorFunctionURL
+	app *fiber.App
+	// StreamThreshold is the response body size, in bytes, at or above
+	// which a buffered fiber response's Content-Length header is left out
+	// of the streaming prelude; smaller buffered responses carry their
+	// Content-Length. Every response is POSTed to the runtime with chunked
+	// Transfer-Encoding, whatever its size. Handlers that call
+	// fctx.Response.SetBodyStream directly always stream regardless of
+	// size. Defaults to DefaultStreamThreshold.
+	StreamThreshold int
+
+	runtimeAPI string
+	client     *http.Client
+}
+
+// NewStreaming creates a new FiberLambdaStreaming object.
+// Receives an initialized *fiber.App object - normally created with fiber.New().
+// The Runtime API endpoint is read from the AWS_LAMBDA_RUNTIME_API
+// environment variable Lambda sets for every invocation.
+func NewStreaming(app *fiber.App) *FiberLambdaStreaming {
+	return &FiberLambdaStreaming{
+		app:             app,
+		StreamThreshold: DefaultStreamThreshold,
+		runtimeAPI:      os.Getenv(runtimeAPIEnvVar),
+		client:          http.DefaultClient,
+	}
+}
+
+// Start polls the Lambda Runtime API for the next Function URL invocation,
+// handles it, and streams the response back, looping until a Runtime API
+// communication failure. Use it in place of lambda.Start, which has no
+// response-streaming support.
+func (f *FiberLambdaStreaming) Start() error {
+	for {
+		if err := f.handleNextInvocation(); err != nil {
+			return err
+		}
+	}
+}
+
+func (f *FiberLambdaStreaming) handleNextInvocation() error {
+	requestID, payload, err := f.nextInvocation()
+	if err != nil {
+		return err
+	}
+
+	var funcURLReq events.LambdaFunctionURLRequest
+	if jsonErr := json.Unmarshal(payload, &funcURLReq); jsonErr != nil {
+		return f.postInvocationError(requestID, jsonErr)
+	}
+
+	prelude, body, invokeErr := f.invoke(funcURLReq)
+	if invokeErr != nil {
+		return f.postInvocationError(requestID, invokeErr)
+	}
+
+	return f.postStreamingResponse(requestID, prelude, body)
+}
+
+// fctxBodyStream wraps a fasthttp response body stream so closing it
+// releases the stream's underlying resources (a file, pipe, or connection
+// a handler may have passed to fctx.Response.SetBodyStream) via
+// fctx.Response.CloseBodyStream, instead of merely draining it.
+type fctxBodyStream struct {
+	io.Reader
+	fctx *fasthttp.RequestCtx
+}
+
+func (s *fctxBodyStream) Close() error {
+	return s.fctx.Response.CloseBodyStream()
+}
+
+// invoke converts req into an http.Request, runs it through the fiber.App,
+// and returns the prelude describing the response plus its body. A panic
+// inside the fiber.App is recovered and turned into a generic 500, as
+// FiberLambdaALB does when its RecoveryHandler is nil, since there is no
+// per-invocation caller here to hand the recovered value to instead.
+func (f *FiberLambdaStreaming) invoke(req events.LambdaFunctionURLRequest) (prelude streamingPrelude, body io.ReadCloser, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = core.NewLoggedError("panic while handling function URL request: %v\n%s", recovered, debug.Stack())
+			prelude = streamingPrelude{StatusCode: http.StatusInternalServerError}
+			body = io.NopCloser(bytes.NewReader([]byte(http.StatusText(http.StatusInternalServerError))))
+			err = nil
+		}
+	}()
+
+	httpRequest, reqErr := f.EventToRequestWithContext(context.Background(), req)
+	if reqErr != nil {
+		return streamingPrelude{}, nil, fmt.Errorf("could not convert function URL event to request: %v", reqErr)
+	}
+
+	fastReq := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(fastReq)
+
+	fastReq.Header.SetMethod(httpRequest.Method)
+	fastReq.SetRequestURI(httpRequest.RequestURI)
+	fastReq.SetHost(httpRequest.Host)
+	for header, values := range httpRequest.Header {
+		for _, v := range values {
+			fastReq.Header.Add(header, v)
+		}
+	}
+	written, copyErr := io.Copy(fastReq.BodyWriter(), httpRequest.Body)
+	if copyErr != nil {
+		return streamingPrelude{}, nil, fmt.Errorf("could not read function URL request body: %v", copyErr)
+	}
+	fastReq.Header.SetContentLength(int(written))
+
+	remoteAddr, addrErr := net.ResolveTCPAddr("tcp", httpRequest.RemoteAddr)
+	if addrErr != nil {
+		remoteAddr, _ = net.ResolveTCPAddr("tcp", "127.0.0.1:0")
+	}
+
+	// As in the ALB adaptor, this RequestCtx isn't pooled; here, in addition,
+	// its response body may still be read by postStreamingResponse, via
+	// fctx.Response.BodyStream(), after this method returns.
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Init(fastReq, remoteAddr, nil)
+
+	f.app.Handler()(fctx)
+
+	headers := make(map[string]string)
+	var cookies []string
+	fctx.Response.Header.VisitAll(func(k, v []byte) {
+		if string(k) == fiber.HeaderSetCookie {
+			cookies = append(cookies, string(v))
+			return
+		}
+		headers[string(k)] = string(v)
+	})
+
+	prelude = streamingPrelude{
+		StatusCode: fctx.Response.StatusCode(),
+		Headers:    headers,
+		Cookies:    cookies,
+	}
+
+	if stream := fctx.Response.BodyStream(); stream != nil {
+		return prelude, &fctxBodyStream{Reader: stream, fctx: fctx}, nil
+	}
+
+	buffered := fctx.Response.Body()
+	if len(buffered) >= f.streamThreshold() {
+		delete(headers, fiber.HeaderContentLength)
+	} else {
+		headers[fiber.HeaderContentLength] = strconv.Itoa(len(buffered))
+	}
+	return prelude, io.NopCloser(bytes.NewReader(buffered)), nil
+}
+
+func (f *FiberLambdaStreaming) streamThreshold() int {
+	if f.StreamThreshold > 0 {
+		return f.StreamThreshold
+	}
+	return DefaultStreamThreshold
+}
+
+// nextInvocation polls the Runtime API for the next event, returning the
+// AWS request ID the response must be POSTed back under and the raw event
+// payload.
+func (f *FiberLambdaStreaming) nextInvocation() (string, []byte, error) {
+	resp, err := f.client.Get(fmt.Sprintf("http://%s/2018-06-01/runtime/invocation/next", f.runtimeAPI))
+	if err != nil {
+		return "", nil, fmt.Errorf("could not poll for next invocation: %v", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not read invocation payload: %v", err)
+	}
+
+	return resp.Header.Get("Lambda-Runtime-Aws-Request-Id"), payload, nil
+}
+
+// postStreamingResponse POSTs prelude and body back to the Runtime API in
+// streaming mode: chunked Transfer-Encoding, with the JSON prelude followed
+// by an 8-byte null separator ahead of the body, as
+// streamingResponseModeHeader requires.
+func (f *FiberLambdaStreaming) postStreamingResponse(requestID string, prelude streamingPrelude, body io.ReadCloser) error {
+	preludeJSON, err := json.Marshal(prelude)
+	if err != nil {
+		return fmt.Errorf("could not marshal streaming prelude: %v", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write(preludeJSON)
+		_, _ = pw.Write(make([]byte, 8)) // null-byte separator
+		_, copyErr := io.Copy(pw, body)
+		// Release whatever the handler's stream was backed by (a file,
+		// pipe, or connection set via fctx.Response.SetBodyStream) now
+		// that it's been fully drained into pw.
+		if closeErr := body.Close(); copyErr == nil {
+			copyErr = closeErr
+		}
+		_ = pw.CloseWithError(copyErr)
+	}()
+
+	url := fmt.Sprintf("http://%s/2018-06-01/runtime/invocation/%s/response", f.runtimeAPI, requestID)
+	req, err := http.NewRequest(http.MethodPost, url, pr)
+	if err != nil {
+		return fmt.Errorf("could not build streaming response request: %v", err)
+	}
+	req.Header.Set(streamingResponseModeHeader, streamingResponseMode)
+	req.Header.Set("Content-Type", streamingContentType)
+	req.TransferEncoding = []string{"chunked"}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not post streaming response: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// postInvocationError reports a failed invocation to the Runtime API so
+// Lambda surfaces it instead of waiting for the invocation to time out.
+func (f *FiberLambdaStreaming) postInvocationError(requestID string, invokeErr error) error {
+	payload, _ := json.Marshal(map[string]string{
+		"errorMessage": invokeErr.Error(),
+		"errorType":    "FiberLambdaStreamingError",
+	})
+
+	url := fmt.Sprintf("http://%s/2018-06-01/runtime/invocation/%s/error", f.runtimeAPI, requestID)
+	resp, err := f.client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("could not post invocation error: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
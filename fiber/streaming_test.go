@@ -0,0 +1,162 @@
+package fiberadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gofiber/fiber/v2"
+)
+
+func funcURLGetRequest(path string) events.LambdaFunctionURLRequest {
+	return events.LambdaFunctionURLRequest{
+		RawPath: path,
+		RequestContext: events.LambdaFunctionURLRequestContext{
+			DomainName: "example.com",
+			HTTP: events.LambdaFunctionURLRequestContextHTTP{
+				Method: http.MethodGet,
+			},
+		},
+	}
+}
+
+// TestStreamingPostResponseFraming drives invoke() and postStreamingResponse()
+// against a fake Runtime API and asserts the POSTed body matches the
+// streaming prelude format: a JSON prelude, an 8-byte null separator, then
+// the response body.
+func TestStreamingPostResponseFraming(t *testing.T) {
+	var captured []byte
+	runtimeAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("fake runtime API: could not read posted body: %v", err)
+		}
+		captured = body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer runtimeAPI.Close()
+
+	app := fiber.New()
+	app.Get("/ping", func(c *fiber.Ctx) error {
+		c.Set("X-Test", "yes")
+		c.Cookie(&fiber.Cookie{Name: "session", Value: "abc"})
+		return c.SendString("hello")
+	})
+
+	f := NewStreaming(app)
+	f.runtimeAPI = strings.TrimPrefix(runtimeAPI.URL, "http://")
+	f.client = runtimeAPI.Client()
+
+	prelude, body, err := f.invoke(funcURLGetRequest("/ping"))
+	if err != nil {
+		t.Fatalf("unexpected error from invoke: %v", err)
+	}
+	if err := f.postStreamingResponse("req-1", prelude, body); err != nil {
+		t.Fatalf("unexpected error from postStreamingResponse: %v", err)
+	}
+
+	sep := make([]byte, 8)
+	idx := bytes.Index(captured, sep)
+	if idx < 0 {
+		t.Fatalf("null-byte separator not found in posted body: %q", captured)
+	}
+
+	var gotPrelude streamingPrelude
+	if err := json.Unmarshal(captured[:idx], &gotPrelude); err != nil {
+		t.Fatalf("could not unmarshal prelude JSON: %v", err)
+	}
+	if gotPrelude.StatusCode != fiber.StatusOK {
+		t.Errorf("expected prelude status 200, got %d", gotPrelude.StatusCode)
+	}
+	if gotPrelude.Headers["X-Test"] != "yes" {
+		t.Errorf("expected X-Test header in prelude, got %v", gotPrelude.Headers)
+	}
+	if len(gotPrelude.Cookies) != 1 || !strings.HasPrefix(gotPrelude.Cookies[0], "session=abc") {
+		t.Errorf("expected session cookie in prelude, got %v", gotPrelude.Cookies)
+	}
+
+	gotBody := captured[idx+8:]
+	if string(gotBody) != "hello" {
+		t.Errorf("expected posted body %q, got %q", "hello", gotBody)
+	}
+}
+
+// TestStreamThresholdDropsContentLength asserts a buffered response at or
+// above StreamThreshold has its Content-Length header dropped from the
+// streaming prelude.
+func TestStreamThresholdDropsContentLength(t *testing.T) {
+	app := fiber.New()
+	app.Get("/big", func(c *fiber.Ctx) error {
+		return c.SendString(strings.Repeat("a", 100))
+	})
+
+	f := NewStreaming(app)
+	f.StreamThreshold = 10
+
+	prelude, body, err := f.invoke(funcURLGetRequest("/big"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	if _, ok := prelude.Headers[fiber.HeaderContentLength]; ok {
+		t.Errorf("expected Content-Length header to be dropped for a buffered body over StreamThreshold")
+	}
+}
+
+// TestStreamThresholdKeepsContentLength asserts a buffered response under
+// StreamThreshold keeps its Content-Length header.
+func TestStreamThresholdKeepsContentLength(t *testing.T) {
+	app := fiber.New()
+	app.Get("/small", func(c *fiber.Ctx) error {
+		return c.SendString("hi")
+	})
+
+	f := NewStreaming(app)
+	f.StreamThreshold = 1024
+
+	prelude, body, err := f.invoke(funcURLGetRequest("/small"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	if _, ok := prelude.Headers[fiber.HeaderContentLength]; !ok {
+		t.Errorf("expected Content-Length header to be kept for a buffered body under StreamThreshold")
+	}
+}
+
+// TestStreamingInvokeRecoversPanic mirrors FiberLambdaALB's recovery tests
+// for the invoke() path, which has no per-invocation caller to hand a
+// recovered value to and so always falls back to a generic 500.
+func TestStreamingInvokeRecoversPanic(t *testing.T) {
+	app := fiber.New()
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		panic("kaboom")
+	})
+
+	f := NewStreaming(app)
+
+	prelude, body, err := f.invoke(funcURLGetRequest("/boom"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	if prelude.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", prelude.StatusCode)
+	}
+
+	got, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("could not read recovered body: %v", err)
+	}
+	if string(got) != http.StatusText(http.StatusInternalServerError) {
+		t.Errorf("unexpected recovered body: %q", got)
+	}
+}
@@ -0,0 +1,159 @@
+package fiberadapter
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"sync"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+)
+
+// Fiber Locals keys set on every request proxied through ProxyWebSocket, so
+// handlers can read back the API Gateway WebSocket metadata and push
+// messages to the client.
+const (
+	LocalsKeyConnectionID = "apigwws.connectionId"
+	LocalsKeyStage        = "apigwws.stage"
+	LocalsKeyDomainName   = "apigwws.domainName"
+	LocalsKeyRouteKey     = "apigwws.routeKey"
+	LocalsKeyConnSender   = "apigwws.connSender"
+)
+
+// websocketAccessor converts API Gateway WebSocket proxy events into
+// http.Request objects; it holds no state, so a single instance is reused
+// across invocations.
+var websocketAccessor = core.NewRequestAccessorWebsocket()
+
+// ProxyWebSocket receives an API Gateway WebSocket proxy event, transforms
+// it into an http.Request for its route key ($connect, $disconnect,
+// $default, or a custom route selection key), and sends it to the
+// fiber.App for routing. It returns a proxy response object generated from
+// the http.ResponseWriter.
+func (f *FiberLambdaALB) ProxyWebSocket(req events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return f.ProxyWebSocketWithContext(context.Background(), req)
+}
+
+// ProxyWebSocketWithContext receives a context and an API Gateway WebSocket
+// proxy event, transforms them into an http.Request object, and sends it
+// to the fiber.App for routing. It returns a proxy response object
+// generated from the http.ResponseWriter.
+func (f *FiberLambdaALB) ProxyWebSocketWithContext(ctx context.Context, req events.APIGatewayWebsocketProxyRequest) (resp events.APIGatewayProxyResponse, respErr error) {
+	httpRequest, err := websocketAccessor.EventToRequestWithContext(ctx, req)
+	if err != nil {
+		return core.GatewayTimeoutWebsocket(), core.NewLoggedError("Could not convert websocket event to request: %v", err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			stack := debug.Stack()
+			if f.WebSocketRecoveryHandler != nil {
+				resp, respErr = f.WebSocketRecoveryHandler(ctx, req, recovered, stack), nil
+				return
+			}
+			resp, respErr = synthesizeWebsocket500Response(), nil
+		}
+	}()
+
+	respWriter := core.NewProxyResponseWriterWebsocket()
+	f.adaptorWebsocket(respWriter, httpRequest, req)
+
+	proxyResponse, err := respWriter.GetProxyResponse()
+	if err != nil {
+		return core.GatewayTimeoutWebsocket(), core.NewLoggedError("Error while generating proxy response: %v", err)
+	}
+
+	return proxyResponse, nil
+}
+
+// AWSSession returns the session used to build the ConnectionSender passed
+// to handlers, creating a default one on first use. Lazily creating it here
+// is safe under concurrent Lambda extension mode: awsSessionOnce ensures
+// only one goroutine ever calls session.NewSession, even if several
+// invocations race to read a nil f.awsSession at once.
+func (f *FiberLambdaALB) AWSSession() *session.Session {
+	f.awsSessionOnce.Do(func() {
+		if f.awsSession == nil {
+			f.awsSession = session.Must(session.NewSession())
+		}
+	})
+	return f.awsSession
+}
+
+// WithAWSSession overrides the session used to build the ConnectionSender
+// passed to handlers, e.g. to point at a local API Gateway Management API
+// endpoint in tests. Call it before the first ProxyWebSocket invocation;
+// it isn't safe to call concurrently with AWSSession.
+func (f *FiberLambdaALB) WithAWSSession(sess *session.Session) *FiberLambdaALB {
+	f.awsSession = sess
+	return f
+}
+
+// adaptorWebsocket mirrors adaptor, but additionally exposes the
+// connectionId, stage, domain name, and a ConnectionSender on the Fiber
+// context Locals so handlers can identify and message back to the client.
+func (f *FiberLambdaALB) adaptorWebsocket(w http.ResponseWriter, r *http.Request, event events.APIGatewayWebsocketProxyRequest) {
+	reqCtx := event.RequestContext
+
+	fastReq := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(fastReq)
+
+	// r.Body was already built from event.Body by
+	// core.RequestAccessorWebsocket.EventToRequestWithContext, which
+	// base64-decodes it when event.IsBase64Encoded; read it from there
+	// instead of re-decoding event.Body a second time here.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), fiber.StatusInternalServerError)
+		return
+	}
+
+	fastReq.Header.SetMethod(r.Method)
+	fastReq.SetRequestURI(r.RequestURI)
+	fastReq.Header.SetContentLength(len(body))
+	_, _ = fastReq.BodyWriter().Write(body)
+	for key, val := range r.Header {
+		for _, v := range val {
+			fastReq.Header.Add(key, v)
+		}
+	}
+
+	if f.forwardClient != nil {
+		f.forward(w, fastReq)
+		return
+	}
+	if f.app == nil {
+		http.Error(w, "FiberLambdaALB has no fiber.App configured for WebSocket routing", fiber.StatusInternalServerError)
+		return
+	}
+
+	remoteAddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
+	if err != nil {
+		http.Error(w, err.Error(), fiber.StatusInternalServerError)
+		return
+	}
+
+	var fctx fasthttp.RequestCtx
+	fctx.Init(fastReq, remoteAddr, nil)
+
+	fctx.SetUserValue(LocalsKeyConnectionID, reqCtx.ConnectionID)
+	fctx.SetUserValue(LocalsKeyStage, reqCtx.Stage)
+	fctx.SetUserValue(LocalsKeyDomainName, reqCtx.DomainName)
+	fctx.SetUserValue(LocalsKeyRouteKey, reqCtx.RouteKey)
+	fctx.SetUserValue(LocalsKeyConnSender, core.NewConnectionSender(f.AWSSession(), reqCtx.DomainName, reqCtx.Stage))
+
+	f.app.Handler()(&fctx)
+
+	fctx.Response.Header.VisitAll(func(k, v []byte) {
+		w.Header().Add(string(k), string(v))
+	})
+	w.WriteHeader(fctx.Response.StatusCode())
+	_, _ = w.Write(fctx.Response.Body())
+}
@@ -0,0 +1,149 @@
+package fiberadapter
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+)
+
+func TestProxyWebSocketRouteKeys(t *testing.T) {
+	app := fiber.New()
+	app.Get("/$connect", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/$disconnect", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/$default", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/sendMessage", func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals(LocalsKeyConnectionID).(string))
+	})
+
+	lambdaFiber := NewALB(app)
+
+	tests := []struct {
+		name     string
+		routeKey string
+	}{
+		{"connect", "$connect"},
+		{"disconnect", "$disconnect"},
+		{"default", "$default"},
+		{"custom route", "sendMessage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := events.APIGatewayWebsocketProxyRequest{
+				RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+					RouteKey:     tt.routeKey,
+					ConnectionID: "abc123",
+					DomainName:   "example.execute-api.us-east-1.amazonaws.com",
+					Stage:        "prod",
+				},
+			}
+
+			resp, err := lambdaFiber.ProxyWebSocketWithContext(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != fiber.StatusOK {
+				t.Fatalf("expected status 200 for route %q, got %d", tt.routeKey, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestProxyWebSocketDecodesBase64Body(t *testing.T) {
+	app := fiber.New()
+	app.Get("/$default", func(c *fiber.Ctx) error {
+		return c.Send(c.BodyRaw())
+	})
+
+	lambdaFiber := NewALB(app)
+
+	binaryBody := []byte{0x00, 0x01, 0xff, 0xfe, 'h', 'i'}
+	req := events.APIGatewayWebsocketProxyRequest{
+		RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+			RouteKey:     "$default",
+			ConnectionID: "abc123",
+		},
+		Body:            base64.StdEncoding.EncodeToString(binaryBody),
+		IsBase64Encoded: true,
+	}
+
+	resp, err := lambdaFiber.ProxyWebSocketWithContext(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if resp.Body != string(binaryBody) {
+		t.Fatalf("expected decoded binary body %q, got %q", binaryBody, resp.Body)
+	}
+}
+
+func TestProxyWebSocketExposesConnectionSender(t *testing.T) {
+	app := fiber.New()
+	app.Get("/$connect", func(c *fiber.Ctx) error {
+		sender, ok := c.Locals(LocalsKeyConnSender).(*core.ConnectionSender)
+		if !ok || sender == nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	lambdaFiber := NewALB(app)
+
+	req := events.APIGatewayWebsocketProxyRequest{
+		RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+			RouteKey:     "$connect",
+			ConnectionID: "abc123",
+			DomainName:   "example.execute-api.us-east-1.amazonaws.com",
+			Stage:        "prod",
+		},
+	}
+
+	resp, err := lambdaFiber.ProxyWebSocketWithContext(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected a non-nil *core.ConnectionSender in Locals, got status %d", resp.StatusCode)
+	}
+}
+
+func TestRequestAccessorWebsocketRouteKeyToPath(t *testing.T) {
+	accessor := core.NewRequestAccessorWebsocket()
+
+	tests := []struct {
+		routeKey string
+		wantPath string
+	}{
+		{"$connect", "/$connect"},
+		{"$disconnect", "/$disconnect"},
+		{"$default", "/$default"},
+		{"sendMessage", "/sendMessage"},
+	}
+
+	for _, tt := range tests {
+		req := events.APIGatewayWebsocketProxyRequest{
+			RequestContext: events.APIGatewayWebsocketProxyRequestContext{RouteKey: tt.routeKey},
+		}
+
+		httpReq, err := accessor.EventToRequestWithContext(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error for route %q: %v", tt.routeKey, err)
+		}
+		if httpReq.URL.Path != tt.wantPath {
+			t.Errorf("route %q: expected path %q, got %q", tt.routeKey, tt.wantPath, httpReq.URL.Path)
+		}
+	}
+}